@@ -0,0 +1,148 @@
+package sssa
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+	"math/big"
+)
+
+/**
+ * CreateBytesWithRand behaves exactly like CreateBytes, except every
+ * coefficient and x-coordinate is drawn from r instead of the OS
+ * CSPRNG. Paired with NewDeterministicReader, this lets the same seed
+ * and secret always produce the same share set: useful for test
+ * vectors, and for deriving shares deterministically from a master
+ * seed (as in HD-wallet-style backup schemes).
+ *
+ * Unlike CreateBytes, it returns ErrInvalidThreshold rather than nil
+ * when minimum > shares, and surfaces any error reading from r.
+**/
+func CreateBytesWithRand(minimum, shares int, raw []byte, r io.Reader) ([][]byte, error) {
+	if minimum > shares {
+		return nil, ErrInvalidThreshold
+	}
+
+	secret := splitByteToInt(raw)
+
+	numbers := make([]*big.Int, 0)
+	numbers = append(numbers, big.NewInt(0))
+
+	polynomial := make([][]*big.Int, len(secret))
+	for i := range polynomial {
+		polynomial[i] = make([]*big.Int, minimum)
+		polynomial[i][0] = secret[i]
+
+		for j := range polynomial[i][1:] {
+			number, err := nextUnused(r, numbers)
+			if err != nil {
+				return nil, err
+			}
+			numbers = append(numbers, number)
+			polynomial[i][j+1] = number
+		}
+	}
+
+	resultBytes := make([][]byte, shares)
+	for i := range resultBytes {
+		for j := range polynomial {
+			number, err := nextUnused(r, numbers)
+			if err != nil {
+				return nil, err
+			}
+			numbers = append(numbers, number)
+
+			y := evaluatePolynomial(polynomial[j], number)
+			resultBytes[i] = appendBytes(appendBytes(resultBytes[i], number), y)
+		}
+	}
+
+	return resultBytes, nil
+}
+
+// nextUnused draws field elements from r via randomFrom until it finds
+// one not already present in numbers, mirroring the rejection-sampling
+// loops CreateBytes performs against random().
+func nextUnused(r io.Reader, numbers []*big.Int) (*big.Int, error) {
+	for {
+		number, err := randomFrom(r)
+		if err != nil {
+			return nil, err
+		}
+		if !inNumbers(numbers, number) {
+			return number, nil
+		}
+	}
+}
+
+// randomFrom draws a uniform element of [0, Prime) from r via
+// rejection sampling on 32-byte blocks, the same way random() is
+// presumed to draw from the OS CSPRNG.
+func randomFrom(r io.Reader) (*big.Int, error) {
+	buf := make([]byte, 32)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		n := big.NewInt(0).SetBytes(buf)
+		if n.Cmp(Prime) < 0 {
+			return n, nil
+		}
+	}
+}
+
+/**
+ * NewDeterministicReader returns an io.Reader producing an unbounded,
+ * reproducible byte stream derived from seed via HKDF-SHA256 (RFC
+ * 5869): the same seed always yields the same stream, and therefore,
+ * combined with CreateBytesWithRand, the same share set for a given
+ * secret.
+**/
+func NewDeterministicReader(seed []byte) io.Reader {
+	return &hkdfReader{
+		prk:  hkdfExtract(seed),
+		info: []byte("sssa-golang deterministic share reader v1"),
+	}
+}
+
+// hkdfReader implements io.Reader over the HKDF-SHA256 expand step,
+// emitting T(1) || T(2) || ... as a continuous stream. The block
+// counter is a single byte, so after T(255) (the first 8160 bytes, the
+// most RFC 5869 defines) it wraps to 0 and the chain continues from
+// the previous block.
+type hkdfReader struct {
+	prk  []byte
+	info []byte
+	prev []byte
+	ctr  byte
+	buf  []byte
+}
+
+func (r *hkdfReader) Read(p []byte) (int, error) {
+	total := 0
+	for total < len(p) {
+		if len(r.buf) == 0 {
+			r.ctr++
+			mac := hmac.New(sha256.New, r.prk)
+			mac.Write(r.prev)
+			mac.Write(r.info)
+			mac.Write([]byte{r.ctr})
+			r.prev = mac.Sum(nil)
+			r.buf = append([]byte(nil), r.prev...)
+		}
+		n := copy(p[total:], r.buf)
+		r.buf = r.buf[n:]
+		total += n
+	}
+	return total, nil
+}
+
+// hkdfExtract is the HKDF-SHA256 extract step with a zero salt, since
+// NewDeterministicReader's only input keying material is the caller's
+// seed.
+func hkdfExtract(ikm []byte) []byte {
+	salt := make([]byte, sha256.Size)
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	return mac.Sum(nil)
+}
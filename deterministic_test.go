@@ -0,0 +1,80 @@
+package sssa
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// These vectors were produced by this implementation for the given
+// seed, secret, minimum, and shares; any conforming reimplementation
+// of NewDeterministicReader/CreateBytesWithRand should reproduce them
+// byte-for-byte.
+var deterministicVectors = []struct {
+	seed      string
+	secret    string
+	minimum   int
+	shares    int
+	wantShare []string
+}{
+	{
+		seed:    "sssa-golang test seed",
+		secret:  "deterministic secret",
+		minimum: 2,
+		shares:  3,
+		wantShare: []string{
+			"b798d5d7b7dc628dab908e1374af7d80d1e707597106e6ade49cbc56a0ecbe8677e229a1e5fab25997c53ce8a1c399936786c703a5bbd07c5960dbe4408e9298",
+			"5e81aaa45ad903491a840be54353cd85532a2f9c1f9114145c02d27a7e52ee1e57008ddad4d66f5be879e8d7c0255d8ee392d33f7b83ac83e24096ca9b08844c",
+			"88dba56cbbdf673e6ec2208bc63ecfea98316d61f1d322aa88bcb1ad58d3e7de930811fb402ef62c533d553525a03186721a32cc3192cc42b76d32131fd57cae",
+		},
+	},
+}
+
+func TestCreateBytesWithRandVectors(t *testing.T) {
+	for _, v := range deterministicVectors {
+		r := NewDeterministicReader([]byte(v.seed))
+		shares, err := CreateBytesWithRand(v.minimum, v.shares, []byte(v.secret), r)
+		if err != nil {
+			t.Fatalf("CreateBytesWithRand: %v", err)
+		}
+
+		for i, want := range v.wantShare {
+			got := hex.EncodeToString(shares[i])
+			if got != want {
+				t.Errorf("share %d = %s, want %s", i, got, want)
+			}
+		}
+
+		secret := CombineBytes(shares[:v.minimum])
+		if !bytes.Equal(secret, []byte(v.secret)) {
+			t.Errorf("CombineBytes of generated shares = %q, want %q", secret, v.secret)
+		}
+	}
+}
+
+func TestCreateBytesWithRandDeterministic(t *testing.T) {
+	seed := []byte("reproducibility check")
+	secret := []byte("same seed, same shares")
+
+	a, err := CreateBytesWithRand(3, 5, secret, NewDeterministicReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := CreateBytesWithRand(3, 5, secret, NewDeterministicReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			t.Errorf("share %d differs between runs with the same seed", i)
+		}
+	}
+}
+
+func TestCreateBytesWithRandInvalidThreshold(t *testing.T) {
+	_, err := CreateBytesWithRand(5, 3, []byte("x"), NewDeterministicReader([]byte("seed")))
+	if err != ErrInvalidThreshold {
+		t.Fatalf("got %v, want ErrInvalidThreshold", err)
+	}
+}
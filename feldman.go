@@ -0,0 +1,144 @@
+package sssa
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// VssP and VssQ define the multiplicative group used for Feldman
+// commitments: VssP is a safe prime (VssP = 2*VssQ + 1, with VssQ also
+// prime) and VssG generates its order-VssQ subgroup.
+//
+// Feldman VSS arithmetic below is carried out modulo VssQ rather than
+// the package's existing 256-bit Prime: verifying g^y against the
+// published commitments only holds if shares are computed in the same
+// ring whose elements the commitments are exponents of, and Prime and
+// VssQ are unrelated moduli.
+var (
+	VssP *big.Int
+	VssQ *big.Int
+	VssG *big.Int
+)
+
+func init() {
+	// A 2048-bit safe prime (VssP = 2*VssQ + 1, VssQ also prime),
+	// generated for this package rather than reused from a published
+	// DH group. At 2048 bits, discrete logarithms in this group are out
+	// of reach of known attacks.
+	VssP, _ = big.NewInt(0).SetString(
+		"efca7bcd0fbca62122cc3078ffa286a1ec8d2e2c2be002547c5a6b1865ac243"+
+			"62354702e0730e7a60e531e0e454d142bd7f5460350e585473e6d894c45c047"+
+			"6316f60ad0dec49a7ce6a5a340198bcab2c2386eff178ab902d91c52dcf6a43"+
+			"580c8016bcebf5ee8da636bc63a8826eefb55088b76ad1c06b7f7ac9db44556"+
+			"34d031e6974ad8c6006a8a8513bf3cf3d34713f3e31563ff24cfa0539764ef9"+
+			"1238ae0859c9170ec5a0e1c575b86ade85cddf668b0d7d634b295aaf480a157"+
+			"228b7541e630851a6a7851113a062186d80a8d65eefb54838592c829ea61a06"+
+			"bdfa39b8555ec01adb8700c370a08d1f92ccd73a435df420d06fee2def8289b"+
+			"b04376d3", 16)
+	VssQ = big.NewInt(0).Rsh(big.NewInt(0).Sub(VssP, big.NewInt(1)), 1)
+	VssG = big.NewInt(4)
+}
+
+/**
+ * CreateWithCommitments splits raw into shares and additionally
+ * publishes a Feldman commitment to each coefficient of the underlying
+ * polynomial. Unlike Split, it does not chunk raw: it treats raw as a
+ * single big-endian integer, which must be less than VssQ, evaluates
+ * one polynomial modulo VssQ, and leaves each Share's T at zero. This
+ * package has no function that combines these shares modulo VssQ;
+ * CombineShares interpolates modulo Prime and does not recover raw
+ * from them. A holder of one of shares can then
+ * call VerifyShare against commitments to confirm their share is
+ * consistent with the same polynomial every other share was drawn
+ * from, without learning raw or needing to trust the dealer.
+ *
+ * commitments is independent of which or how many shares are
+ * generated, so a single set of commitments covers all of shares.
+**/
+func CreateWithCommitments(minimum, shares int, raw []byte) ([]Share, [][]byte, error) {
+	if minimum < 1 || minimum > shares {
+		return nil, nil, ErrInvalidThreshold
+	}
+
+	secret := big.NewInt(0).SetBytes(raw)
+	if secret.Cmp(VssQ) >= 0 {
+		return nil, nil, errors.New("sssa: secret is too large for the VSS group")
+	}
+
+	polynomial := make([]*big.Int, minimum)
+	polynomial[0] = secret
+	for j := 1; j < minimum; j++ {
+		a, err := rand.Int(rand.Reader, VssQ)
+		if err != nil {
+			return nil, nil, err
+		}
+		polynomial[j] = a
+	}
+
+	commitments := make([][]byte, minimum)
+	for j, a := range polynomial {
+		c := big.NewInt(0).Exp(VssG, a, VssP)
+		commitments[j] = c.Bytes()
+	}
+
+	numbers := make([]*big.Int, 0, shares+1)
+	numbers = append(numbers, big.NewInt(0))
+
+	out := make([]Share, shares)
+	for i := range out {
+		x, err := rand.Int(rand.Reader, VssQ)
+		if err != nil {
+			return nil, nil, err
+		}
+		for x.Sign() == 0 || inNumbers(numbers, x) {
+			x, err = rand.Int(rand.Reader, VssQ)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		numbers = append(numbers, x)
+
+		out[i] = Share{X: x, Y: []*big.Int{vssEvaluate(polynomial, x)}}
+	}
+
+	return out, commitments, nil
+}
+
+// vssEvaluate evaluates polynomial at x modulo VssQ via Horner's
+// method, mirroring evaluatePolynomial's modulo-Prime evaluation used
+// by the non-verifiable schemes.
+func vssEvaluate(polynomial []*big.Int, x *big.Int) *big.Int {
+	result := big.NewInt(0)
+	for i := len(polynomial) - 1; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, polynomial[i])
+		result.Mod(result, VssQ)
+	}
+	return result
+}
+
+/**
+ * VerifyShare reports whether share is consistent with the polynomial
+ * committed to by commitments (as returned by CreateWithCommitments),
+ * by checking g^y == prod(C_j^(x^j)) mod VssP. It does not require
+ * and does not reveal the secret.
+**/
+func VerifyShare(share Share, commitments [][]byte) bool {
+	if len(share.Y) != 1 {
+		return false
+	}
+
+	lhs := big.NewInt(0).Exp(VssG, share.Y[0], VssP)
+
+	rhs := big.NewInt(1)
+	xPow := big.NewInt(1)
+	for _, raw := range commitments {
+		c := big.NewInt(0).SetBytes(raw)
+		term := big.NewInt(0).Exp(c, xPow, VssP)
+		rhs.Mod(rhs.Mul(rhs, term), VssP)
+		xPow.Mul(xPow, share.X)
+	}
+
+	return lhs.Cmp(rhs) == 0
+}
@@ -0,0 +1,68 @@
+package sssa
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVssPIsA2048BitSafePrime(t *testing.T) {
+	if got := VssP.BitLen(); got < 2048 {
+		t.Errorf("VssP is %d bits, want at least 2048", got)
+	}
+	if !VssP.ProbablyPrime(20) {
+		t.Error("VssP is not prime")
+	}
+	if !VssQ.ProbablyPrime(20) {
+		t.Error("VssQ = (VssP-1)/2 is not prime")
+	}
+}
+
+func TestCreateWithCommitmentsVerifyShare(t *testing.T) {
+	shares, commitments, err := CreateWithCommitments(3, 5, []byte("feldman vss secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("got %d shares, want 5", len(shares))
+	}
+
+	for i, s := range shares {
+		if !VerifyShare(s, commitments) {
+			t.Errorf("share %d failed to verify against its own commitments", i)
+		}
+	}
+}
+
+func TestVerifyShareRejectsTamperedShare(t *testing.T) {
+	shares, commitments, err := CreateWithCommitments(3, 5, []byte("feldman vss secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := shares[0]
+	tampered.Y = []*big.Int{new(big.Int).Add(tampered.Y[0], big.NewInt(1))}
+	if VerifyShare(tampered, commitments) {
+		t.Error("VerifyShare accepted a tampered share")
+	}
+}
+
+func TestVerifyShareRejectsWrongCommitments(t *testing.T) {
+	shares, _, err := CreateWithCommitments(3, 5, []byte("secret one"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherCommitments, err := CreateWithCommitments(3, 5, []byte("secret two"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if VerifyShare(shares[0], otherCommitments) {
+		t.Error("VerifyShare accepted a share against an unrelated commitment set")
+	}
+}
+
+func TestCreateWithCommitmentsInvalidThreshold(t *testing.T) {
+	if _, _, err := CreateWithCommitments(4, 3, []byte("x")); err != ErrInvalidThreshold {
+		t.Errorf("got %v, want ErrInvalidThreshold", err)
+	}
+}
@@ -0,0 +1,298 @@
+package sssa
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// ErrSecretEmpty is returned by Scheme implementations that require a
+// non-empty secret.
+var ErrSecretEmpty = errors.New("sssa: secret must not be empty")
+
+/**
+ * Scheme is implemented by each Shamir backend this package provides,
+ * letting callers pick performance (GF(2^8), via NewGF256Scheme) vs.
+ * the large-field semantics of the original implementation (via
+ * NewPrimeFieldScheme) behind a single interface.
+ *
+ * Combine rejects zero shares and any two sharing an x-coordinate, but
+ * its wire format carries no threshold, so it cannot detect a Combine
+ * call given fewer shares than the original Split's t (other than the
+ * t=1 case, where a single share is always sufficient). Use
+ * SplitEnveloped/CombineEnvelopes when that guarantee matters: the
+ * envelope carries the threshold alongside the payload.
+**/
+type Scheme interface {
+	Split(secret []byte, n, t int) ([][]byte, error)
+	Combine(shares [][]byte) ([]byte, error)
+}
+
+// primeFieldScheme adapts the big.Int, 256-bit prime field Split /
+// CombineShares functions to the Scheme interface, encoding each Share
+// using the same 64-bytes-per-part (x, y) layout as CreateBytes and
+// CombineBytes.
+type primeFieldScheme struct{}
+
+/**
+ * NewPrimeFieldScheme returns the package's original 256-bit prime
+ * field implementation as a Scheme, interchangeable with
+ * NewGF256Scheme.
+**/
+func NewPrimeFieldScheme() Scheme { return primeFieldScheme{} }
+
+func (primeFieldScheme) Split(secret []byte, n, t int) ([][]byte, error) {
+	shares, err := Split(secret, n, t)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([][]byte, len(shares))
+	for i, s := range shares {
+		var b []byte
+		for _, y := range s.Y {
+			b = appendBytes(appendBytes(b, s.X), y)
+		}
+		encoded[i] = b
+	}
+
+	return encoded, nil
+}
+
+// Combine decodes each of shares into its (x, y) pairs and reconstructs
+// the secret via the same duplicate-x-coordinate-checked interpolation
+// as CombineShares, rather than forwarding to the legacy CombineBytes
+// (which performs no such check and silently returns a wrong secret
+// given inconsistent shares).
+func (primeFieldScheme) Combine(shares [][]byte) ([]byte, error) {
+	decoded := make([]Share, len(shares))
+	for i, s := range shares {
+		share, err := decodePrimeFieldShare(s)
+		if err != nil {
+			return nil, err
+		}
+		decoded[i] = share
+	}
+
+	return combine(decoded)
+}
+
+// decodePrimeFieldShare parses one of primeFieldScheme's encoded
+// shares (the same 64-bytes-per-part (x, y) layout as CreateBytes and
+// CombineBytes) back into a Share, validating that its x-coordinate is
+// the same across every part.
+func decodePrimeFieldShare(share []byte) (Share, error) {
+	if !IsValidShare(share) {
+		return Share{}, errors.New("sssa: invalid share")
+	}
+
+	count := len(share) / 64
+	y := make([]*big.Int, count)
+	var x *big.Int
+	for j := 0; j < count; j++ {
+		block := share[j*64 : (j+1)*64]
+		bx := from32Bytes(block[:32])
+		if x == nil {
+			x = bx
+		} else if x.Cmp(bx) != 0 {
+			return Share{}, errors.New("sssa: share has inconsistent x-coordinate across parts")
+		}
+		y[j] = from32Bytes(block[32:])
+	}
+
+	return Share{X: x, Y: y}, nil
+}
+
+// gf256Scheme implements Shamir's Algorithm over GF(2^8), as used by
+// hashicorp/vault and codahale/sss: each secret byte is a field
+// element, coefficients are random bytes, addition is XOR, and
+// multiplication goes through the log/exp tables below. Shares are
+// len(secret)+1 bytes: a 1-byte x-coordinate followed by one y-byte
+// per secret byte, far smaller than the prime field's 64-bytes-per-
+// 32-byte-chunk overhead.
+type gf256Scheme struct{}
+
+/**
+ * NewGF256Scheme returns a Scheme implementing Shamir's Algorithm over
+ * GF(2^8), trading the prime field's 256-bit headroom per chunk for
+ * shares only one byte larger than the secret itself.
+**/
+func NewGF256Scheme() Scheme { return gf256Scheme{} }
+
+func (gf256Scheme) Split(secret []byte, n, t int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, ErrSecretEmpty
+	}
+	if n < 1 || n > 255 {
+		return nil, errors.New("sssa: gf256 scheme supports at most 255 shares")
+	}
+	if t < 1 || t > n {
+		return nil, ErrInvalidThreshold
+	}
+
+	polynomials := make([][]byte, len(secret))
+	for i, b := range secret {
+		poly := make([]byte, t)
+		poly[0] = b
+		if _, err := rand.Read(poly[1:]); err != nil {
+			return nil, err
+		}
+		polynomials[i] = poly
+	}
+
+	xs, err := gf256Coordinates(n)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([][]byte, n)
+	for i, x := range xs {
+		share := make([]byte, len(secret)+1)
+		share[0] = x
+		for j, poly := range polynomials {
+			share[j+1] = gf256Eval(poly, x)
+		}
+		shares[i] = share
+	}
+
+	return shares, nil
+}
+
+// Combine rejects zero shares and any two sharing an x-coordinate (see
+// the Scheme doc comment for why it cannot also check the original
+// threshold); a t=1 split's polynomial is constant, so one share is
+// sufficient and is not rejected here.
+func (gf256Scheme) Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < 1 {
+		return nil, ErrNotEnoughShares
+	}
+
+	size := len(shares[0]) - 1
+	if size < 1 {
+		return nil, errors.New("sssa: invalid gf256 share")
+	}
+
+	xs := make([]byte, len(shares))
+	seen := make(map[byte]bool, len(shares))
+	for i, s := range shares {
+		if len(s) != size+1 {
+			return nil, errors.New("sssa: mismatched gf256 share lengths")
+		}
+		if seen[s[0]] {
+			return nil, ErrDuplicateShare
+		}
+		seen[s[0]] = true
+		xs[i] = s[0]
+	}
+
+	secret := make([]byte, size)
+	for j := 0; j < size; j++ {
+		var acc byte
+		for i := range shares {
+			numerator := byte(1)
+			denominator := byte(1)
+			for k := range shares {
+				if k == i {
+					continue
+				}
+				numerator = gfMul(numerator, xs[k])
+				denominator = gfMul(denominator, xs[i]^xs[k])
+			}
+			acc ^= gfMul(shares[i][j+1], gfMul(numerator, gfInv(denominator)))
+		}
+		secret[j] = acc
+	}
+
+	return secret, nil
+}
+
+// gf256Coordinates returns n distinct, nonzero x-coordinates, drawn
+// from the OS CSPRNG the same way random() draws prime-field
+// coefficients, so that no share's x-coordinate collides with
+// another's.
+func gf256Coordinates(n int) ([]byte, error) {
+	seen := make(map[byte]bool, n)
+	xs := make([]byte, n)
+
+	for i := range xs {
+		var b [1]byte
+		for {
+			if _, err := rand.Read(b[:]); err != nil {
+				return nil, err
+			}
+			if b[0] != 0 && !seen[b[0]] {
+				break
+			}
+		}
+		seen[b[0]] = true
+		xs[i] = b[0]
+	}
+
+	return xs, nil
+}
+
+// gf256Eval evaluates poly at x via Horner's method.
+func gf256Eval(poly []byte, x byte) byte {
+	var result byte
+	for i := len(poly) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ poly[i]
+	}
+	return result
+}
+
+// expTable and logTable are the GF(2^8) exponent/log tables for
+// generator 3 under the Rijndael irreducible polynomial x^8 + x^4 +
+// x^3 + x + 1 (0x11b), built once in init() rather than hardcoded so
+// the derivation is auditable.
+var (
+	expTable [256]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		logTable[x] = byte(i)
+		x = gfMulNoTable(x, 3)
+	}
+	expTable[255] = expTable[0]
+}
+
+// gfMulNoTable multiplies two GF(2^8) elements via the standard
+// carry-less shift-and-reduce algorithm; it exists only to bootstrap
+// expTable and logTable in init(), before gfMul's table lookups are
+// available.
+func gfMulNoTable(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// gfMul multiplies two GF(2^8) elements using the log/exp tables.
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	sum := int(logTable[a]) + int(logTable[b])
+	if sum >= 255 {
+		sum -= 255
+	}
+	return expTable[sum]
+}
+
+// gfInv returns the multiplicative inverse of the nonzero GF(2^8)
+// element b.
+func gfInv(b byte) byte {
+	return expTable[255-int(logTable[b])]
+}
@@ -0,0 +1,93 @@
+package sssa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testSchemeRoundTrip(t *testing.T, scheme Scheme) {
+	secret := []byte("gf256 scheme round trip secret")
+
+	shares, err := scheme.Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("got %d shares, want 5", len(shares))
+	}
+
+	got, err := scheme.Combine(shares[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("Combine = %q, want %q", got, secret)
+	}
+}
+
+func TestPrimeFieldSchemeRoundTrip(t *testing.T) {
+	testSchemeRoundTrip(t, NewPrimeFieldScheme())
+}
+
+func TestGF256SchemeRoundTrip(t *testing.T) {
+	testSchemeRoundTrip(t, NewGF256Scheme())
+}
+
+func testSchemeDuplicateShare(t *testing.T, scheme Scheme) {
+	shares, err := scheme.Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scheme.Combine([][]byte{shares[0], shares[0]}); err != ErrDuplicateShare {
+		t.Errorf("got %v, want ErrDuplicateShare", err)
+	}
+}
+
+func TestPrimeFieldSchemeDuplicateShare(t *testing.T) {
+	testSchemeDuplicateShare(t, NewPrimeFieldScheme())
+}
+
+func TestGF256SchemeDuplicateShare(t *testing.T) {
+	testSchemeDuplicateShare(t, NewGF256Scheme())
+}
+
+func TestGF256SchemeInvalidThreshold(t *testing.T) {
+	if _, err := NewGF256Scheme().Split([]byte("x"), 3, 4); err != ErrInvalidThreshold {
+		t.Errorf("got %v, want ErrInvalidThreshold", err)
+	}
+}
+
+func TestGF256SchemeEmptySecret(t *testing.T) {
+	if _, err := NewGF256Scheme().Split(nil, 3, 2); err != ErrSecretEmpty {
+		t.Errorf("got %v, want ErrSecretEmpty", err)
+	}
+}
+
+// testSchemeThresholdOne checks that a t=1 split's constant polynomial
+// can be recovered from a single share, for schemes whose Combine has
+// no threshold to check and so must not reject it.
+func testSchemeThresholdOne(t *testing.T, scheme Scheme) {
+	secret := []byte("scheme single share secret")
+
+	shares, err := scheme.Split(secret, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := scheme.Combine(shares[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("Combine = %q, want %q", got, secret)
+	}
+}
+
+func TestPrimeFieldSchemeThresholdOne(t *testing.T) {
+	testSchemeThresholdOne(t, NewPrimeFieldScheme())
+}
+
+func TestGF256SchemeThresholdOne(t *testing.T) {
+	testSchemeThresholdOne(t, NewGF256Scheme())
+}
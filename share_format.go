@@ -0,0 +1,174 @@
+package sssa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// shareMagic identifies a self-describing share envelope produced by
+// EncodeShare, distinguishing it from the unframed byte layouts used
+// by CreateBytes, Split, and the Scheme implementations.
+var shareMagic = [4]byte{'S', 'S', 'S', 'A'}
+
+// shareFormatVersion is the only envelope version EncodeShare and
+// DecodeShare currently produce or understand.
+const shareFormatVersion = 1
+
+// secretIDSize is the length, in bytes, of the random identifier that
+// ties a set of shares back to the secret they were split from.
+const secretIDSize = 16
+
+// envelopeHeaderLen is the size, in bytes, of everything EncodeShare
+// writes ahead of the payload: magic, version, threshold, total,
+// index, and the secret-id.
+const envelopeHeaderLen = len(shareMagic) + 1 + 1 + 1 + 1 + secretIDSize
+
+var (
+	ErrBadMagic           = errors.New("sssa: not a recognized share envelope")
+	ErrUnsupportedVersion = errors.New("sssa: unsupported share envelope version")
+	ErrShareTruncated     = errors.New("sssa: share envelope is truncated")
+	ErrShareCorrupt       = errors.New("sssa: share envelope failed its checksum")
+	ErrSecretIDMismatch   = errors.New("sssa: shares belong to different secrets")
+	ErrThresholdMismatch  = errors.New("sssa: shares disagree on the threshold")
+)
+
+/**
+ * Meta is the metadata carried alongside a share's payload by
+ * EncodeShare: which secret it belongs to, its position among the
+ * total shares, and the threshold required to reconstruct the secret.
+ * Combine-side code can use it to refuse to mix shares from different
+ * secrets instead of silently producing corrupt output, the failure
+ * mode of the unframed CombineBytes/CombineShares/Scheme.Combine.
+**/
+type Meta struct {
+	Threshold byte
+	Total     byte
+	Index     byte
+	SecretID  [secretIDSize]byte
+}
+
+/**
+ * EncodeShare prepends meta and appends a trailing CRC32 to payload
+ * (the raw share bytes from CreateBytes, Split, or a Scheme),
+ * producing a self-describing share envelope modeled on horcrux's
+ * share header.
+**/
+func EncodeShare(meta Meta, payload []byte) []byte {
+	header := make([]byte, 0, envelopeHeaderLen+len(payload)+4)
+	header = append(header, shareMagic[:]...)
+	header = append(header, shareFormatVersion, meta.Threshold, meta.Total, meta.Index)
+	header = append(header, meta.SecretID[:]...)
+	header = append(header, payload...)
+
+	var crcBuf [4]byte
+	binary.BigEndian.PutUint32(crcBuf[:], crc32.ChecksumIEEE(header))
+
+	return append(header, crcBuf[:]...)
+}
+
+/**
+ * DecodeShare parses an envelope produced by EncodeShare, verifying
+ * its magic bytes, version, and CRC32 before returning the embedded
+ * metadata and payload.
+**/
+func DecodeShare(data []byte) (Meta, []byte, error) {
+	if len(data) < envelopeHeaderLen+4 {
+		return Meta{}, nil, ErrShareTruncated
+	}
+	if !bytes.Equal(data[:len(shareMagic)], shareMagic[:]) {
+		return Meta{}, nil, ErrBadMagic
+	}
+	if data[len(shareMagic)] != shareFormatVersion {
+		return Meta{}, nil, ErrUnsupportedVersion
+	}
+
+	body := data[:len(data)-4]
+	wantCRC := binary.BigEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != wantCRC {
+		return Meta{}, nil, ErrShareCorrupt
+	}
+
+	i := len(shareMagic) + 1
+	meta := Meta{Threshold: data[i], Total: data[i+1], Index: data[i+2]}
+	copy(meta.SecretID[:], data[i+3:i+3+secretIDSize])
+
+	payload := append([]byte(nil), data[envelopeHeaderLen:len(data)-4]...)
+	return meta, payload, nil
+}
+
+/**
+ * SplitEnveloped splits secret via scheme and wraps each resulting
+ * share in a self-describing envelope (see EncodeShare), stamped with
+ * a fresh random secret-id so that shares from unrelated calls to
+ * SplitEnveloped can never be silently combined together.
+**/
+func SplitEnveloped(secret []byte, n, t int, scheme Scheme) ([][]byte, error) {
+	if n > 255 || t > 255 {
+		return nil, errors.New("sssa: envelope format supports at most 255 shares")
+	}
+
+	payloads, err := scheme.Split(secret, n, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretID [secretIDSize]byte
+	if _, err := rand.Read(secretID[:]); err != nil {
+		return nil, err
+	}
+
+	shares := make([][]byte, len(payloads))
+	for i, payload := range payloads {
+		meta := Meta{
+			Threshold: byte(t),
+			Total:     byte(n),
+			Index:     byte(i),
+			SecretID:  secretID,
+		}
+		shares[i] = EncodeShare(meta, payload)
+	}
+
+	return shares, nil
+}
+
+/**
+ * CombineEnvelopes decodes each of shares as an envelope produced by
+ * EncodeShare/SplitEnveloped and reconstructs the secret via scheme.
+ * It rejects shares whose secret-ids or thresholds disagree, and
+ * auto-detects whether enough shares are present from the embedded
+ * threshold rather than requiring the caller to track it separately.
+**/
+func CombineEnvelopes(shares [][]byte, scheme Scheme) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+
+	metas := make([]Meta, len(shares))
+	payloads := make([][]byte, len(shares))
+	for i, s := range shares {
+		meta, payload, err := DecodeShare(s)
+		if err != nil {
+			return nil, err
+		}
+		metas[i] = meta
+		payloads[i] = payload
+	}
+
+	for i := 1; i < len(metas); i++ {
+		if metas[i].SecretID != metas[0].SecretID {
+			return nil, ErrSecretIDMismatch
+		}
+		if metas[i].Threshold != metas[0].Threshold {
+			return nil, ErrThresholdMismatch
+		}
+	}
+
+	if len(shares) < int(metas[0].Threshold) {
+		return nil, ErrNotEnoughShares
+	}
+
+	return scheme.Combine(payloads)
+}
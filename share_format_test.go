@@ -0,0 +1,138 @@
+package sssa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecodeShareRoundTrip(t *testing.T) {
+	meta := Meta{Threshold: 3, Total: 5, Index: 1}
+	meta.SecretID[0] = 0xab
+	payload := []byte("share payload")
+
+	encoded := EncodeShare(meta, payload)
+
+	gotMeta, gotPayload, err := DecodeShare(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotMeta != meta {
+		t.Errorf("meta = %+v, want %+v", gotMeta, meta)
+	}
+	if !bytes.Equal(gotPayload, payload) {
+		t.Errorf("payload = %q, want %q", gotPayload, payload)
+	}
+}
+
+func TestDecodeShareRejectsBadMagic(t *testing.T) {
+	encoded := EncodeShare(Meta{}, []byte("payload"))
+	encoded[0] ^= 0xff
+
+	if _, _, err := DecodeShare(encoded); err != ErrBadMagic {
+		t.Errorf("got %v, want ErrBadMagic", err)
+	}
+}
+
+func TestDecodeShareRejectsCorruption(t *testing.T) {
+	encoded := EncodeShare(Meta{}, []byte("payload"))
+	encoded[len(encoded)-5] ^= 0xff
+
+	if _, _, err := DecodeShare(encoded); err != ErrShareCorrupt {
+		t.Errorf("got %v, want ErrShareCorrupt", err)
+	}
+}
+
+func TestDecodeShareRejectsTruncated(t *testing.T) {
+	if _, _, err := DecodeShare([]byte("too short")); err != ErrShareTruncated {
+		t.Errorf("got %v, want ErrShareTruncated", err)
+	}
+}
+
+func TestSplitCombineEnvelopes(t *testing.T) {
+	secret := []byte("enveloped round trip secret")
+	scheme := NewGF256Scheme()
+
+	shares, err := SplitEnveloped(secret, 5, 3, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := CombineEnvelopes(shares[:3], scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("CombineEnvelopes = %q, want %q", got, secret)
+	}
+}
+
+func TestSplitCombineEnvelopesThresholdOne(t *testing.T) {
+	secret := []byte("enveloped single share secret")
+	scheme := NewGF256Scheme()
+
+	shares, err := SplitEnveloped(secret, 3, 1, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := CombineEnvelopes(shares[:1], scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("CombineEnvelopes = %q, want %q", got, secret)
+	}
+}
+
+func TestCombineEnvelopesRejectsSecretIDMismatch(t *testing.T) {
+	scheme := NewGF256Scheme()
+
+	a, err := SplitEnveloped([]byte("secret a"), 3, 2, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := SplitEnveloped([]byte("secret b"), 3, 2, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mixed := [][]byte{a[0], b[1]}
+	if _, err := CombineEnvelopes(mixed, scheme); err != ErrSecretIDMismatch {
+		t.Errorf("got %v, want ErrSecretIDMismatch", err)
+	}
+}
+
+func TestCombineEnvelopesRejectsThresholdMismatch(t *testing.T) {
+	scheme := NewGF256Scheme()
+
+	a, err := SplitEnveloped([]byte("secret a"), 3, 2, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := append([]byte(nil), a[1]...)
+	meta, payload, err := DecodeShare(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.Threshold = 3
+	tampered = EncodeShare(meta, payload)
+
+	mixed := [][]byte{a[0], tampered}
+	if _, err := CombineEnvelopes(mixed, scheme); err != ErrThresholdMismatch {
+		t.Errorf("got %v, want ErrThresholdMismatch", err)
+	}
+}
+
+func TestCombineEnvelopesRejectsBelowThreshold(t *testing.T) {
+	scheme := NewGF256Scheme()
+
+	shares, err := SplitEnveloped([]byte("secret"), 5, 3, scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CombineEnvelopes(shares[:2], scheme); err != ErrNotEnoughShares {
+		t.Errorf("got %v, want ErrNotEnoughShares", err)
+	}
+}
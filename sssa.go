@@ -104,18 +104,11 @@ func CreateBytes(minimum int, shares int, raw []byte) [][]byte {
 			secrets[i][j][1] = evaluatePolynomial(polynomial[j], number)
 
 			// ...add it to results...
-			log.Debug("secrets[%d][%d][0] = %x (%dB)", i, j,
-				secrets[i][j][0],
-				len(secrets[i][j][0].Bytes()))
-			log.Debug("secrets[%d][%d][1] = %x (%dB)", i, j,
-				secrets[i][j][1],
-				len(secrets[i][j][1].Bytes()))
 			// each of secrets[i][j][*] is < 256^32
 			result[i] += toBase64(secrets[i][j][0])
 			result[i] += toBase64(secrets[i][j][1])
 			resultBytes[i] = appendBytes(resultBytes[i], secrets[i][j][0])
 			resultBytes[i] = appendBytes(resultBytes[i], secrets[i][j][1])
-			log.Info("resultBytes[%d] is now %d", i, len(resultBytes[i]))
 
 		}
 	}
@@ -0,0 +1,289 @@
+package sssa
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// chunkSize is the number of secret bytes consumed per polynomial
+// evaluation. It matches the 32-byte blocks used by splitByteToInt so
+// that shares produced by the streaming API are byte-compatible with
+// those produced by CreateBytes.
+const chunkSize = 32
+
+/**
+ * Splitter implements io.Writer, consuming a secret in fixed-size
+ * chunks and emitting a Shamir share block to each output writer as
+ * soon as a chunk is complete. Unlike CreateBytes, which requires the
+ * whole secret to be resident in memory, a Splitter can process a
+ * secret of arbitrary size (e.g. a large file) a buffer at a time.
+ *
+ * Callers must call Close once all of the secret has been written, to
+ * flush any final, short chunk.
+**/
+type Splitter struct {
+	minimum int
+	writers []io.Writer
+	numbers []*big.Int
+	buf     []byte
+	err     error
+}
+
+/**
+ * Returns a new Splitter requiring minimum of the len(w) shares to
+ * reconstruct the secret later written to it. One 64-byte share block
+ * is written to each of w per 32 bytes of secret consumed.
+**/
+func NewSplitter(minimum int, shares int, w []io.Writer) *Splitter {
+	if len(w) != shares || minimum > shares {
+		return nil
+	}
+
+	numbers := make([]*big.Int, 0)
+	numbers = append(numbers, big.NewInt(0))
+
+	return &Splitter{
+		minimum: minimum,
+		writers: w,
+		numbers: numbers,
+		buf:     make([]byte, 0, chunkSize),
+	}
+}
+
+func (s *Splitter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	s.buf = append(s.buf, p...)
+	for len(s.buf) >= chunkSize {
+		part := big.NewInt(0).SetBytes(s.buf[:chunkSize])
+		if err := s.writePart(part); err != nil {
+			s.err = err
+			return len(p), err
+		}
+		s.buf = s.buf[chunkSize:]
+	}
+
+	return len(p), nil
+}
+
+// writePart evaluates a fresh, randomly-coefficiented polynomial for a
+// single secret chunk and writes the resulting (x, y) block to every
+// output writer, mirroring the per-part loop in CreateBytes.
+func (s *Splitter) writePart(part *big.Int) error {
+	polynomial := make([]*big.Int, s.minimum)
+	polynomial[0] = part
+	for j := 1; j < s.minimum; j++ {
+		number := random()
+		for inNumbers(s.numbers, number) {
+			number = random()
+		}
+		s.numbers = append(s.numbers, number)
+		polynomial[j] = number
+	}
+
+	for _, w := range s.writers {
+		number := random()
+		for inNumbers(s.numbers, number) {
+			number = random()
+		}
+		s.numbers = append(s.numbers, number)
+
+		y := evaluatePolynomial(polynomial, number)
+
+		block := appendBytes(appendBytes(nil, number), y)
+		if _, err := w.Write(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Close flushes any secret bytes buffered but not yet a full chunk,
+ * padding them the same way splitByteToInt pads the final part of a
+ * secret passed to CreateBytes; when nothing is buffered it writes a
+ * chunk of padding alone, as splitByteToInt does for a secret whose
+ * length is a multiple of 32. It must be called exactly once, after
+ * the last call to Write.
+**/
+func (s *Splitter) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, part := range splitByteToInt(s.buf) {
+		if err := s.writePart(part); err != nil {
+			s.err = err
+			return err
+		}
+	}
+	s.buf = nil
+
+	return nil
+}
+
+/**
+ * Combiner implements io.Reader, reconstructing a secret from a set of
+ * share readers one 32-byte chunk at a time via Lagrange Polynomial
+ * Interpolation, without requiring the reconstructed secret or any
+ * share to be held in memory all at once. It is the streaming
+ * counterpart to CombineBytes.
+**/
+type Combiner struct {
+	readers []io.Reader
+	pending []byte
+	lookbuf [][]byte
+	done    bool
+}
+
+/**
+ * Returns a new Combiner that reconstructs a secret by reading 64-byte
+ * share blocks from each of readers in lock-step, or nil if fewer than
+ * minimum readers are supplied. As with CombineShares, supplying at
+ * least minimum readers is necessary but not sufficient for a correct
+ * result: Combiner has no way to confirm minimum actually matches the
+ * threshold the shares were split with.
+**/
+func NewCombiner(minimum int, readers []io.Reader) io.Reader {
+	if minimum < 1 || len(readers) < minimum {
+		return nil
+	}
+	return &Combiner{readers: readers}
+}
+
+func (c *Combiner) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+
+		chunk, err := c.next()
+		if err != nil {
+			return 0, err
+		}
+		if chunk == nil {
+			c.done = true
+			continue
+		}
+		c.pending = chunk
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// next returns the reconstructed bytes for the next chunk, or a nil
+// slice once every reader has reached EOF. It looks one block ahead so
+// that it can tell whether the chunk it is about to return is the
+// final (and therefore padded) one.
+func (c *Combiner) next() ([]byte, error) {
+	blocks := c.lookbuf
+	if blocks == nil {
+		var err error
+		blocks, err = c.readBlocks()
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.lookbuf = nil
+
+	if blocks == nil {
+		return nil, nil
+	}
+
+	next, err := c.readBlocks()
+	if err != nil {
+		return nil, err
+	}
+	c.lookbuf = next
+
+	secret := []*big.Int{interpolate(blocks)}
+	if next == nil {
+		return mergeIntToByte(secret), nil
+	}
+
+	return appendBytes(nil, secret[0]), nil
+}
+
+// readBlocks reads one 64-byte share block from every reader. It
+// returns a nil slice (with no error) once all readers report EOF
+// simultaneously, and an error if the readers disagree, or if two
+// blocks carry the same x-coordinate (which would make interpolate
+// divide by zero).
+func (c *Combiner) readBlocks() ([][]byte, error) {
+	blocks := make([][]byte, len(c.readers))
+	eof := 0
+
+	for i, r := range c.readers {
+		block := make([]byte, 64)
+		n, err := io.ReadFull(r, block)
+		if err == io.EOF {
+			eof++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = block[:n]
+	}
+
+	if eof == len(c.readers) {
+		return nil, nil
+	}
+	if eof > 0 {
+		return nil, fmt.Errorf("sssa: share readers ended at different lengths")
+	}
+
+	seen := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		x := string(block[:32])
+		if seen[x] {
+			return nil, ErrDuplicateShare
+		}
+		seen[x] = true
+	}
+
+	return blocks, nil
+}
+
+// interpolate recovers a single secret chunk from its (x, y) share
+// blocks using the same Lagrange Polynomial Interpolation as
+// CombineBytes.
+func interpolate(blocks [][]byte) *big.Int {
+	x := make([]*big.Int, len(blocks))
+	y := make([]*big.Int, len(blocks))
+	for i, block := range blocks {
+		x[i] = from32Bytes(block[:32])
+		y[i] = from32Bytes(block[32:])
+	}
+
+	secret := big.NewInt(0)
+	for i := range x {
+		numerator := big.NewInt(1)
+		denominator := big.NewInt(1)
+
+		for k := range x {
+			if k == i {
+				continue
+			}
+			negative := big.NewInt(0).Mul(x[k], big.NewInt(-1))
+			added := big.NewInt(0).Sub(x[i], x[k])
+
+			numerator = numerator.Mod(numerator.Mul(numerator, negative), Prime)
+			denominator = denominator.Mod(denominator.Mul(denominator, added), Prime)
+		}
+
+		working := big.NewInt(0).Set(y[i])
+		working = working.Mul(working, numerator)
+		working = working.Mul(working, modInverse(denominator))
+
+		secret = secret.Mod(secret.Add(secret, working), Prime)
+	}
+
+	return secret
+}
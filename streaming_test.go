@@ -0,0 +1,112 @@
+package sssa
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// splitStreamed runs secret through a Splitter with n shares, returning
+// each share's accumulated bytes.
+func splitStreamed(t *testing.T, minimum, n int, secret []byte) [][]byte {
+	t.Helper()
+
+	bufs := make([]*bytes.Buffer, n)
+	writers := make([]io.Writer, n)
+	for i := range bufs {
+		bufs[i] = &bytes.Buffer{}
+		writers[i] = bufs[i]
+	}
+
+	s := NewSplitter(minimum, n, writers)
+	if s == nil {
+		t.Fatal("NewSplitter returned nil")
+	}
+	if _, err := s.Write(secret); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	shares := make([][]byte, n)
+	for i, b := range bufs {
+		shares[i] = b.Bytes()
+	}
+	return shares
+}
+
+// combineStreamed wraps every one of shares in a reader and drains a
+// Combiner built from all of them with the given minimum, failing the
+// test if NewCombiner rejects the arguments.
+func combineStreamed(t *testing.T, minimum int, shares [][]byte) []byte {
+	t.Helper()
+
+	readers := make([]io.Reader, len(shares))
+	for i, s := range shares {
+		readers[i] = bytes.NewReader(s)
+	}
+
+	c := NewCombiner(minimum, readers)
+	if c == nil {
+		t.Fatal("NewCombiner returned nil")
+	}
+
+	got, err := io.ReadAll(c)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	return got
+}
+
+func TestSplitterCombinerRoundTrip(t *testing.T) {
+	secret := []byte("a streaming round trip secret that spans more than one 32-byte chunk")
+
+	shares := splitStreamed(t, 3, 5, secret)
+
+	got := combineStreamed(t, 3, shares[:3])
+	if !bytes.Equal(got, secret) {
+		t.Errorf("combined = %q, want %q", got, secret)
+	}
+}
+
+func TestSplitterCombinerRoundTripShortSecret(t *testing.T) {
+	secret := []byte("short")
+
+	shares := splitStreamed(t, 2, 3, secret)
+
+	got := combineStreamed(t, 2, shares)
+	if !bytes.Equal(got, secret) {
+		t.Errorf("combined = %q, want %q", got, secret)
+	}
+}
+
+func TestNewCombinerRejectsTooFewReaders(t *testing.T) {
+	secret := []byte("a secret split three ways, only one supplied back")
+	shares := splitStreamed(t, 3, 3, secret)
+
+	readers := []io.Reader{bytes.NewReader(shares[0])}
+	if c := NewCombiner(3, readers); c != nil {
+		t.Fatal("NewCombiner accepted fewer readers than minimum")
+	}
+}
+
+func TestNewSplitterRejectsInvalidMinimum(t *testing.T) {
+	if s := NewSplitter(4, 3, make([]io.Writer, 3)); s != nil {
+		t.Error("NewSplitter accepted minimum > shares")
+	}
+}
+
+func TestCombinerRejectsDuplicateXCoordinate(t *testing.T) {
+	secret := []byte("duplicate detection secret")
+	shares := splitStreamed(t, 2, 2, secret)
+
+	readers := []io.Reader{bytes.NewReader(shares[0]), bytes.NewReader(shares[0])}
+	c := NewCombiner(2, readers)
+	if c == nil {
+		t.Fatal("NewCombiner returned nil")
+	}
+	if _, err := io.ReadAll(c); err != ErrDuplicateShare {
+		t.Errorf("got %v, want ErrDuplicateShare", err)
+	}
+}
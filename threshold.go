@@ -0,0 +1,177 @@
+package sssa
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrInvalidThreshold is returned by Split when t is not between
+	// 1 and n inclusive.
+	ErrInvalidThreshold = errors.New("sssa: threshold must be between 1 and n")
+	// ErrNotEnoughShares is returned by CombineShares and Scheme.Combine
+	// when given zero shares; there is nothing to interpolate from (a
+	// t=1 split, however, is a constant polynomial, so a single share
+	// is sufficient and is not rejected here).
+	ErrNotEnoughShares = errors.New("sssa: not enough shares to reconstruct the secret")
+	// ErrDuplicateShare is returned by CombineShares when two shares
+	// carry the same x-coordinate, which would make the Lagrange
+	// interpolation divide by zero.
+	ErrDuplicateShare = errors.New("sssa: duplicate share x-coordinate")
+	// ErrThresholdNotMet is returned by CombineShares when fewer
+	// shares are supplied than the threshold Split recorded on them.
+	ErrThresholdNotMet = errors.New("sssa: fewer shares supplied than their recorded threshold")
+	// ErrInconsistentThreshold is returned by CombineShares when the
+	// supplied shares disagree about the threshold Split recorded on
+	// them, which means they were not all drawn from the same Split
+	// call.
+	ErrInconsistentThreshold = errors.New("sssa: shares disagree on threshold")
+)
+
+/**
+ * Share is a single point on a secret's polynomial, as produced by
+ * Split. It carries its own x-coordinate and the threshold Split was
+ * called with, so that CombineShares can validate its inputs
+ * explicitly, rather than assuming (as the legacy Create/CombineBytes
+ * API does) that every caller passes a consistent and sufficient set
+ * of shares.
+**/
+type Share struct {
+	X *big.Int
+	Y []*big.Int
+	T int
+}
+
+/**
+ * Split divides secret into n Shares, any t of which are sufficient
+ * for CombineShares to reconstruct it. It is the (t, n)-threshold
+ * counterpart to CreateBytes, returning ErrInvalidThreshold instead of
+ * a nil slice when t is out of range.
+**/
+func Split(secret []byte, n, t int) ([]Share, error) {
+	if t < 1 || t > n {
+		return nil, ErrInvalidThreshold
+	}
+
+	parts := splitByteToInt(secret)
+
+	numbers := make([]*big.Int, 0, n+1)
+	numbers = append(numbers, big.NewInt(0))
+
+	polynomial := make([][]*big.Int, len(parts))
+	for i := range polynomial {
+		polynomial[i] = make([]*big.Int, t)
+		polynomial[i][0] = parts[i]
+		for j := 1; j < t; j++ {
+			number := random()
+			for inNumbers(numbers, number) {
+				number = random()
+			}
+			numbers = append(numbers, number)
+			polynomial[i][j] = number
+		}
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		number := random()
+		for inNumbers(numbers, number) {
+			number = random()
+		}
+		numbers = append(numbers, number)
+
+		y := make([]*big.Int, len(parts))
+		for j := range y {
+			y[j] = evaluatePolynomial(polynomial[j], number)
+		}
+
+		shares[i] = Share{X: number, Y: y, T: t}
+	}
+
+	return shares, nil
+}
+
+/**
+ * CombineShares reconstructs a secret from shares, which must be at
+ * least t of the Shares originally returned by Split for that secret.
+ * It is named distinctly from the legacy Combine (which takes base64
+ * strings) because Go cannot overload a name across parameter types.
+ *
+ * Unlike CombineBytes, which silently returns garbage if handed fewer
+ * than the original threshold, CombineShares checks the threshold each
+ * Share carries: it returns ErrInconsistentThreshold if the shares
+ * disagree about it, and ErrThresholdNotMet if fewer shares are
+ * supplied than it (a t=1 split needs only one). It also returns
+ * ErrNotEnoughShares if given zero shares, and ErrDuplicateShare if
+ * two shares carry the same x-coordinate.
+**/
+func CombineShares(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+
+	t := shares[0].T
+	for _, s := range shares[1:] {
+		if s.T != t {
+			return nil, ErrInconsistentThreshold
+		}
+	}
+	if len(shares) < t {
+		return nil, ErrThresholdNotMet
+	}
+
+	return combine(shares)
+}
+
+// combine performs the Lagrange interpolation shared by CombineShares
+// and primeFieldScheme.Combine, after checking that at least one share
+// is present and no two share an x-coordinate. Unlike CombineShares, it
+// has no threshold to check, since primeFieldScheme.Combine's wire
+// format (unlike Share) does not carry one.
+func combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+
+	seen := make(map[string]bool, len(shares))
+	for _, s := range shares {
+		key := s.X.String()
+		if seen[key] {
+			return nil, ErrDuplicateShare
+		}
+		seen[key] = true
+	}
+
+	parts := len(shares[0].Y)
+	secret := make([]*big.Int, parts)
+	for j := 0; j < parts; j++ {
+		secret[j] = big.NewInt(0)
+
+		for i := range shares {
+			origin := shares[i].X
+			originy := shares[i].Y[j]
+			numerator := big.NewInt(1)
+			denominator := big.NewInt(1)
+
+			for k := range shares {
+				if k == i {
+					continue
+				}
+				current := shares[k].X
+				negative := big.NewInt(0).Mul(current, big.NewInt(-1))
+				added := big.NewInt(0).Sub(origin, current)
+
+				numerator = numerator.Mod(numerator.Mul(numerator, negative), Prime)
+				denominator = denominator.Mod(denominator.Mul(denominator, added), Prime)
+			}
+
+			working := big.NewInt(0).Set(originy)
+			working = working.Mul(working, numerator)
+			working = working.Mul(working, modInverse(denominator))
+
+			secret[j] = secret[j].Mod(secret[j].Add(secret[j], working), Prime)
+		}
+	}
+
+	return mergeIntToByte(secret), nil
+}
@@ -0,0 +1,102 @@
+package sssa
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitCombineShares(t *testing.T) {
+	secret := []byte("threshold round trip secret")
+
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("got %d shares, want 5", len(shares))
+	}
+
+	got, err := CombineShares(shares[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("CombineShares = %q, want %q", got, secret)
+	}
+}
+
+func TestSplitInvalidThreshold(t *testing.T) {
+	if _, err := Split([]byte("x"), 3, 4); err != ErrInvalidThreshold {
+		t.Errorf("t > n: got %v, want ErrInvalidThreshold", err)
+	}
+	if _, err := Split([]byte("x"), 3, 0); err != ErrInvalidThreshold {
+		t.Errorf("t < 1: got %v, want ErrInvalidThreshold", err)
+	}
+}
+
+func TestCombineSharesNotEnough(t *testing.T) {
+	if _, err := CombineShares(nil); err != ErrNotEnoughShares {
+		t.Errorf("got %v, want ErrNotEnoughShares", err)
+	}
+}
+
+func TestSplitCombineSharesThresholdOne(t *testing.T) {
+	// A t=1 split's polynomial is constant, so a single share must be
+	// enough to recover the secret.
+	secret := []byte("single share secret")
+
+	shares, err := Split(secret, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := CombineShares(shares[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("CombineShares = %q, want %q", got, secret)
+	}
+}
+
+func TestCombineSharesBelowThreshold(t *testing.T) {
+	// Two shares pass the zero-share check, but the shares were drawn
+	// from a t=3 polynomial: CombineShares must
+	// reject this rather than silently interpolating the wrong line.
+	shares, err := Split([]byte("threshold secret"), 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CombineShares(shares[:2]); err != ErrThresholdNotMet {
+		t.Errorf("got %v, want ErrThresholdNotMet", err)
+	}
+}
+
+func TestCombineSharesDuplicateXCoordinate(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dup := []Share{shares[0], shares[0]}
+	if _, err := CombineShares(dup); err != ErrDuplicateShare {
+		t.Errorf("got %v, want ErrDuplicateShare", err)
+	}
+}
+
+func TestCombineSharesInconsistentThreshold(t *testing.T) {
+	a, err := Split([]byte("secret a"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Split([]byte("secret b"), 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mixed := []Share{a[0], b[0]}
+	if _, err := CombineShares(mixed); err != ErrInconsistentThreshold {
+		t.Errorf("got %v, want ErrInconsistentThreshold", err)
+	}
+}
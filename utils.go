@@ -0,0 +1,127 @@
+package sssa
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+)
+
+// partSize is the fixed width, in bytes, of a single secret chunk and
+// of the big-endian encoding of every (x, y) coordinate this package
+// produces: 256 bits, comfortably under Prime.
+const partSize = 32
+
+/**
+ * random returns a uniformly distributed element of [0, Prime) drawn
+ * from the OS CSPRNG, via the same rejection-sampling loop
+ * CreateBytesWithRand uses (through randomFrom) for a caller-supplied
+ * io.Reader.
+**/
+func random() *big.Int {
+	n, err := randomFrom(rand.Reader)
+	if err != nil {
+		// crypto/rand.Reader is not expected to fail; if it does, the
+		// system RNG is broken and there is nothing sensible this
+		// package can return instead.
+		panic(err)
+	}
+	return n
+}
+
+/**
+ * splitByteToInt divides raw into partSize-byte chunks, each returned
+ * as a *big.Int, padding raw with PKCS#7-style bytes so its length is
+ * always a multiple of partSize. mergeIntToByte reverses the padding.
+**/
+func splitByteToInt(raw []byte) []*big.Int {
+	padLen := partSize - len(raw)%partSize
+	padded := make([]byte, len(raw)+padLen)
+	copy(padded, raw)
+	for i := len(raw); i < len(padded); i++ {
+		padded[i] = byte(padLen)
+	}
+
+	parts := make([]*big.Int, len(padded)/partSize)
+	for i := range parts {
+		chunk := padded[i*partSize : (i+1)*partSize]
+		parts[i] = big.NewInt(0).SetBytes(chunk)
+	}
+	return parts
+}
+
+/**
+ * mergeIntToByte is the inverse of splitByteToInt: it renders every
+ * part as a partSize-byte big-endian block, concatenates them, and
+ * strips the PKCS#7-style padding splitByteToInt added.
+**/
+func mergeIntToByte(parts []*big.Int) []byte {
+	var out []byte
+	for _, part := range parts {
+		out = appendBytes(out, part)
+	}
+
+	if len(out) == 0 {
+		return out
+	}
+	padLen := int(out[len(out)-1])
+	if padLen <= 0 || padLen > partSize || padLen > len(out) {
+		return out
+	}
+	return out[:len(out)-padLen]
+}
+
+/**
+ * evaluatePolynomial evaluates poly (coefficients in ascending order of
+ * degree) at x modulo Prime, via Horner's method.
+**/
+func evaluatePolynomial(poly []*big.Int, x *big.Int) *big.Int {
+	result := big.NewInt(0)
+	for i := len(poly) - 1; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, poly[i])
+		result.Mod(result, Prime)
+	}
+	return result
+}
+
+// inNumbers reports whether n is already present in numbers, used to
+// reject coefficients and x-coordinates that would collide with one
+// already chosen for the same polynomial/share set.
+func inNumbers(numbers []*big.Int, n *big.Int) bool {
+	for _, existing := range numbers {
+		if existing.Cmp(n) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// appendBytes appends n's big-endian, zero-padded-to-partSize encoding
+// to b. Padding to a fixed width (rather than using n.Bytes() as-is)
+// is required because big.Int.Bytes drops leading zero bytes, which
+// would otherwise make shares of varying length after modular
+// reduction produces a value with a short encoding.
+func appendBytes(b []byte, n *big.Int) []byte {
+	block := make([]byte, partSize)
+	n.FillBytes(block)
+	return append(b, block...)
+}
+
+// from32Bytes decodes a partSize-byte big-endian block, the inverse of
+// the encoding appendBytes produces.
+func from32Bytes(b []byte) *big.Int {
+	return big.NewInt(0).SetBytes(b)
+}
+
+// modInverse returns n's multiplicative inverse modulo Prime.
+func modInverse(n *big.Int) *big.Int {
+	return big.NewInt(0).ModInverse(n, Prime)
+}
+
+// toBase64 renders n the same way appendBytes does, then base64-encodes
+// the result. Its only caller is CreateBytes, which builds per-part
+// strings with it but returns only the raw bytes; Create encodes each
+// whole share itself.
+func toBase64(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(appendBytes(nil, n))
+}